@@ -1,6 +1,9 @@
 package bytebufferpool
 
-import "io"
+import (
+	"errors"
+	"io"
+)
 
 // ByteBuffer provides byte buffer, which can be used for minimizing
 // memory allocations.
@@ -14,15 +17,23 @@ type ByteBuffer struct {
 	// B is a byte buffer to use in append-like workloads.
 	// See example code for details.
 	buf []byte
+
+	// readOff is the offset of the next byte to read via Read, ReadByte
+	// or Next. It never exceeds len(buf).
+	readOff int
+
+	// lastByteValid reports whether UnreadByte may undo the most recent
+	// ReadByte call.
+	lastByteValid bool
 }
 
 // NewByteBuffer creates and initializes a new ByteBuffer using buf as its initial
 // contents.
 func NewByteBuffer(buf []byte) *ByteBuffer { return &ByteBuffer{buf: buf} }
 
-// Len returns the size of the byte buffer.
+// Len returns the number of unread bytes in the buffer.
 func (b *ByteBuffer) Len() int {
-	return len(b.buf)
+	return len(b.buf) - b.readOff
 }
 
 // Cap returns the capacity of the buffer's underlying byte slice.
@@ -65,16 +76,23 @@ func (b *ByteBuffer) ReadFrom(r io.Reader) (int64, error) {
 }
 
 // WriteTo implements io.WriterTo.
+//
+// It writes the unread portion of the buffer, i.e. the bytes starting
+// at the current read offset, and advances the read offset past them.
 func (b *ByteBuffer) WriteTo(w io.Writer) (int64, error) {
-	n, err := w.Write(b.buf)
+	n, err := w.Write(b.buf[b.readOff:])
+	b.readOff += n
+	b.lastByteValid = false
+	b.collapse()
 	return int64(n), err
 }
 
-// Bytes returns b.B, i.e. all the bytes accumulated in the buffer.
+// Bytes returns the unread portion of the buffer, i.e. the bytes starting
+// at the current read offset.
 //
 // The purpose of this function is bytes.Buffer compatibility.
 func (b *ByteBuffer) Bytes() []byte {
-	return b.buf
+	return b.buf[b.readOff:]
 }
 
 // Write implements io.Writer - it appends p to ByteBuffer.B
@@ -109,12 +127,116 @@ func (b *ByteBuffer) SetString(s string) {
 	b.buf = append(b.buf[:0], s...)
 }
 
-// String returns string representation of ByteBuffer.B.
+// String returns the unread portion of the buffer as a string, i.e. the
+// bytes starting at the current read offset.
+//
+// The purpose of this function is bytes.Buffer compatibility.
 func (b *ByteBuffer) String() string {
-	return string(b.buf)
+	return string(b.buf[b.readOff:])
 }
 
-// Reset makes ByteBuffer.B empty.
+// Reset empties the buffer and rewinds the read offset to the start.
 func (b *ByteBuffer) Reset() {
 	b.buf = b.buf[:0]
+	b.readOff = 0
+	b.lastByteValid = false
+}
+
+// errUnreadByte is returned by UnreadByte when the last operation wasn't
+// a successful ReadByte.
+var errUnreadByte = errors.New("bytebufferpool: UnreadByte: previous operation was not a successful ReadByte")
+
+// Read implements io.Reader - it reads up to len(p) unread bytes into p,
+// advancing the read offset.
+//
+// Read never returns an error other than io.EOF.
+func (b *ByteBuffer) Read(p []byte) (int, error) {
+	if b.readOff >= len(b.buf) {
+		// As in ReadByte, keep readOff in place while UnreadByte may
+		// still put back the byte returned by the last ReadByte.
+		if !b.lastByteValid {
+			b.collapse()
+		}
+		if len(p) == 0 {
+			return 0, nil
+		}
+		return 0, io.EOF
+	}
+	n := copy(p, b.buf[b.readOff:])
+	b.readOff += n
+	b.lastByteValid = false
+	b.collapse()
+	return n, nil
+}
+
+// ReadByte implements io.ByteReader - it reads and returns the next unread
+// byte. It returns io.EOF if there is nothing left to read.
+func (b *ByteBuffer) ReadByte() (byte, error) {
+	if b.readOff >= len(b.buf) {
+		// Don't collapse while the previously read byte is still
+		// unreadable: collapsing here would zero readOff, making
+		// UnreadByte wrongly believe there's nothing to put back.
+		if !b.lastByteValid {
+			b.collapse()
+		}
+		return 0, io.EOF
+	}
+	c := b.buf[b.readOff]
+	b.readOff++
+	b.lastByteValid = true
+	return c, nil
+}
+
+// UnreadByte unreads the last byte returned by a successful call to
+// ReadByte. It returns an error unless ReadByte has succeeded since the
+// last call to UnreadByte, Reset, Truncate, Next, WriteTo, or a Read that
+// found unread bytes. Writes, and Read or ReadByte calls that find nothing
+// left to read, leave this state unchanged.
+func (b *ByteBuffer) UnreadByte() error {
+	if !b.lastByteValid {
+		return errUnreadByte
+	}
+	b.lastByteValid = false
+	if b.readOff > 0 {
+		b.readOff--
+	}
+	return nil
+}
+
+// Next returns a slice containing the next n unread bytes, advancing the
+// read offset as if the bytes had been returned by Read. If there are
+// fewer than n bytes left, Next returns the whole remainder of the buffer.
+//
+// The returned slice aliases the buffer content, so it is only valid
+// until the next call that mutates or releases the buffer.
+func (b *ByteBuffer) Next(n int) []byte {
+	b.lastByteValid = false
+	m := len(b.buf) - b.readOff
+	if n > m {
+		n = m
+	}
+	data := b.buf[b.readOff : b.readOff+n]
+	b.readOff += n
+	b.collapse()
+	return data
+}
+
+// Truncate discards all but the first n unread bytes from the buffer.
+//
+// It panics if n is negative or greater than the number of unread bytes.
+func (b *ByteBuffer) Truncate(n int) {
+	if n < 0 || b.readOff+n > len(b.buf) {
+		panic("bytebufferpool.ByteBuffer: truncation out of range")
+	}
+	b.lastByteValid = false
+	b.buf = b.buf[:b.readOff+n]
+}
+
+// collapse discards already-read bytes once everything has been consumed,
+// so producer/consumer loops don't grow the underlying array without bound.
+func (b *ByteBuffer) collapse() {
+	if b.readOff > 0 && b.readOff >= len(b.buf) {
+		b.buf = b.buf[:0]
+		b.readOff = 0
+	}
 }
@@ -0,0 +1,104 @@
+package bytebufferpool
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// AppendUvarint appends x to the buffer as a varint and returns the number
+// of bytes written.
+func (b *ByteBuffer) AppendUvarint(x uint64) int {
+	var scratch [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(scratch[:], x)
+	b.buf = append(b.buf, scratch[:n]...)
+	return n
+}
+
+// AppendVarint appends x to the buffer as a zigzag-encoded varint and
+// returns the number of bytes written.
+func (b *ByteBuffer) AppendVarint(x int64) int {
+	var scratch [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(scratch[:], x)
+	b.buf = append(b.buf, scratch[:n]...)
+	return n
+}
+
+// AppendUint16 appends the 2-byte encoding of x to the buffer using order.
+func (b *ByteBuffer) AppendUint16(x uint16, order binary.ByteOrder) {
+	var scratch [2]byte
+	order.PutUint16(scratch[:], x)
+	b.buf = append(b.buf, scratch[:]...)
+}
+
+// AppendUint32 appends the 4-byte encoding of x to the buffer using order.
+func (b *ByteBuffer) AppendUint32(x uint32, order binary.ByteOrder) {
+	var scratch [4]byte
+	order.PutUint32(scratch[:], x)
+	b.buf = append(b.buf, scratch[:]...)
+}
+
+// AppendUint64 appends the 8-byte encoding of x to the buffer using order.
+func (b *ByteBuffer) AppendUint64(x uint64, order binary.ByteOrder) {
+	var scratch [8]byte
+	order.PutUint64(scratch[:], x)
+	b.buf = append(b.buf, scratch[:]...)
+}
+
+// AppendFloat32 appends the 4-byte encoding of x to the buffer using order.
+func (b *ByteBuffer) AppendFloat32(x float32, order binary.ByteOrder) {
+	b.AppendUint32(math.Float32bits(x), order)
+}
+
+// AppendFloat64 appends the 8-byte encoding of x to the buffer using order.
+func (b *ByteBuffer) AppendFloat64(x float64, order binary.ByteOrder) {
+	b.AppendUint64(math.Float64bits(x), order)
+}
+
+// PutUvarint is an alias for AppendUvarint, named after encoding/binary's
+// PutUvarint for callers porting code that called it directly against a
+// scratch []byte.
+func (b *ByteBuffer) PutUvarint(x uint64) int { return b.AppendUvarint(x) }
+
+// PutVarint is an alias for AppendVarint, named after encoding/binary's
+// PutVarint.
+func (b *ByteBuffer) PutVarint(x int64) int { return b.AppendVarint(x) }
+
+// PutUint16 is an alias for AppendUint16, named after encoding/binary's
+// ByteOrder.PutUint16.
+func (b *ByteBuffer) PutUint16(x uint16, order binary.ByteOrder) { b.AppendUint16(x, order) }
+
+// PutUint32 is an alias for AppendUint32, named after encoding/binary's
+// ByteOrder.PutUint32.
+func (b *ByteBuffer) PutUint32(x uint32, order binary.ByteOrder) { b.AppendUint32(x, order) }
+
+// PutUint64 is an alias for AppendUint64, named after encoding/binary's
+// ByteOrder.PutUint64.
+func (b *ByteBuffer) PutUint64(x uint64, order binary.ByteOrder) { b.AppendUint64(x, order) }
+
+// PutFloat32 is an alias for AppendFloat32.
+func (b *ByteBuffer) PutFloat32(x float32, order binary.ByteOrder) { b.AppendFloat32(x, order) }
+
+// PutFloat64 is an alias for AppendFloat64.
+func (b *ByteBuffer) PutFloat64(x float64, order binary.ByteOrder) { b.AppendFloat64(x, order) }
+
+// AppendLengthPrefixed reserves a varint length header, calls fn to append
+// the payload, then patches the header with the actual payload length.
+//
+// This lets callers write length-prefixed records without first
+// serializing the payload into a scratch buffer to learn its size.
+func (b *ByteBuffer) AppendLengthPrefixed(fn func(*ByteBuffer)) {
+	hdrStart := len(b.buf)
+
+	var scratch [binary.MaxVarintLen64]byte
+	b.buf = append(b.buf, scratch[:]...)
+	payloadStart := len(b.buf)
+
+	fn(b)
+
+	n := len(b.buf) - payloadStart
+	hdrLen := binary.PutUvarint(scratch[:], uint64(n))
+
+	copy(b.buf[hdrStart+hdrLen:], b.buf[payloadStart:])
+	copy(b.buf[hdrStart:], scratch[:hdrLen])
+	b.buf = b.buf[:hdrStart+hdrLen+n]
+}
@@ -0,0 +1,122 @@
+package bytebufferpool
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestByteBufferAppendUvarint(t *testing.T) {
+	var bb ByteBuffer
+	n := bb.AppendUvarint(300)
+	if n != 2 {
+		t.Fatalf("unexpected varint length: %d. Expecting 2", n)
+	}
+	x, nn := binary.Uvarint(bb.Bytes())
+	if x != 300 || nn != n {
+		t.Fatalf("unexpected decoded varint: %d (%d bytes). Expecting 300 (%d bytes)", x, nn, n)
+	}
+}
+
+func TestByteBufferAppendVarint(t *testing.T) {
+	var bb ByteBuffer
+	bb.AppendVarint(-42)
+	x, n := binary.Varint(bb.Bytes())
+	if x != -42 {
+		t.Fatalf("unexpected decoded varint: %d. Expecting -42", x)
+	}
+	if n != len(bb.Bytes()) {
+		t.Fatalf("unexpected consumed bytes: %d. Expecting %d", n, len(bb.Bytes()))
+	}
+}
+
+func TestByteBufferAppendFixedWidth(t *testing.T) {
+	var bb ByteBuffer
+	bb.AppendUint16(0x1234, binary.BigEndian)
+	bb.AppendUint32(0x12345678, binary.BigEndian)
+	bb.AppendUint64(0x1122334455667788, binary.BigEndian)
+	bb.AppendFloat32(3.14, binary.BigEndian)
+	bb.AppendFloat64(2.71828, binary.BigEndian)
+
+	buf := bb.Bytes()
+	if v := binary.BigEndian.Uint16(buf[0:2]); v != 0x1234 {
+		t.Fatalf("unexpected uint16: %x", v)
+	}
+	if v := binary.BigEndian.Uint32(buf[2:6]); v != 0x12345678 {
+		t.Fatalf("unexpected uint32: %x", v)
+	}
+	if v := binary.BigEndian.Uint64(buf[6:14]); v != 0x1122334455667788 {
+		t.Fatalf("unexpected uint64: %x", v)
+	}
+	if len(buf) != 14+4+8 {
+		t.Fatalf("unexpected total length: %d", len(buf))
+	}
+}
+
+func TestByteBufferPutAliases(t *testing.T) {
+	var bb ByteBuffer
+	bb.PutUvarint(300)
+	bb.PutVarint(-42)
+	bb.PutUint16(0x1234, binary.BigEndian)
+	bb.PutUint32(0x12345678, binary.BigEndian)
+	bb.PutUint64(0x1122334455667788, binary.BigEndian)
+	bb.PutFloat32(3.14, binary.BigEndian)
+	bb.PutFloat64(2.71828, binary.BigEndian)
+
+	buf := bb.Bytes()
+
+	x, n := binary.Uvarint(buf)
+	if x != 300 {
+		t.Fatalf("unexpected decoded uvarint: %d. Expecting 300", x)
+	}
+	buf = buf[n:]
+
+	v, n := binary.Varint(buf)
+	if v != -42 {
+		t.Fatalf("unexpected decoded varint: %d. Expecting -42", v)
+	}
+	buf = buf[n:]
+
+	if v := binary.BigEndian.Uint16(buf[0:2]); v != 0x1234 {
+		t.Fatalf("unexpected uint16: %x", v)
+	}
+	if v := binary.BigEndian.Uint32(buf[2:6]); v != 0x12345678 {
+		t.Fatalf("unexpected uint32: %x", v)
+	}
+	if v := binary.BigEndian.Uint64(buf[6:14]); v != 0x1122334455667788 {
+		t.Fatalf("unexpected uint64: %x", v)
+	}
+	if len(buf) != 14+4+8 {
+		t.Fatalf("unexpected total length: %d", len(buf))
+	}
+}
+
+func TestByteBufferAppendLengthPrefixed(t *testing.T) {
+	var bb ByteBuffer
+	bb.AppendLengthPrefixed(func(b *ByteBuffer) {
+		b.WriteString("hello")
+	})
+
+	n, nn := binary.Uvarint(bb.Bytes())
+	if n != 5 {
+		t.Fatalf("unexpected length prefix: %d. Expecting 5", n)
+	}
+	payload := bb.Bytes()[nn:]
+	if string(payload) != "hello" {
+		t.Fatalf("unexpected payload: %q. Expecting %q", payload, "hello")
+	}
+
+	// the prefix must shrink to fit when the payload is larger than a
+	// single-byte varint can describe.
+	var bb2 ByteBuffer
+	big := make([]byte, 200)
+	bb2.AppendLengthPrefixed(func(b *ByteBuffer) {
+		b.Write(big)
+	})
+	n2, nn2 := binary.Uvarint(bb2.Bytes())
+	if n2 != 200 {
+		t.Fatalf("unexpected length prefix: %d. Expecting 200", n2)
+	}
+	if len(bb2.Bytes()) != nn2+200 {
+		t.Fatalf("unexpected total length: %d. Expecting %d", len(bb2.Bytes()), nn2+200)
+	}
+}
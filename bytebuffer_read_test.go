@@ -0,0 +1,169 @@
+package bytebufferpool
+
+import (
+	"io"
+	"testing"
+)
+
+func TestByteBufferRead(t *testing.T) {
+	var bb ByteBuffer
+	bb.WriteString("foobar")
+
+	p := make([]byte, 3)
+	n, err := bb.Read(p)
+	if err != nil || n != 3 || string(p) != "foo" {
+		t.Fatalf("unexpected first Read: n=%d, err=%v, p=%q", n, err, p)
+	}
+
+	n, err = bb.Read(p)
+	if err != nil || n != 3 || string(p) != "bar" {
+		t.Fatalf("unexpected second Read: n=%d, err=%v, p=%q", n, err, p)
+	}
+
+	n, err = bb.Read(p)
+	if err != io.EOF || n != 0 {
+		t.Fatalf("expected io.EOF after draining the buffer, got n=%d, err=%v", n, err)
+	}
+}
+
+func TestByteBufferReadByteUnreadByte(t *testing.T) {
+	var bb ByteBuffer
+	bb.WriteString("ab")
+
+	c, err := bb.ReadByte()
+	if err != nil || c != 'a' {
+		t.Fatalf("unexpected ReadByte: c=%c, err=%v", c, err)
+	}
+
+	if err := bb.UnreadByte(); err != nil {
+		t.Fatalf("unexpected error from UnreadByte: %s", err)
+	}
+
+	c, err = bb.ReadByte()
+	if err != nil || c != 'a' {
+		t.Fatalf("unexpected ReadByte after UnreadByte: c=%c, err=%v", c, err)
+	}
+
+	if err := bb.UnreadByte(); err != nil {
+		t.Fatalf("unexpected error from UnreadByte: %s", err)
+	}
+	if err := bb.UnreadByte(); err == nil {
+		t.Fatalf("expected an error from a second consecutive UnreadByte")
+	}
+}
+
+// TestByteBufferUnreadByteAtEnd guards against ReadByte auto-collapsing the
+// buffer when the byte it just returned is still the last byte, i.e. when
+// readOff reaches len(buf) exactly on a successful read. Collapsing there
+// would zero readOff, so a following UnreadByte would report success
+// without actually restoring the byte, and the next read would wrongly see
+// io.EOF instead of the unread byte.
+func TestByteBufferUnreadByteAtEnd(t *testing.T) {
+	var bb ByteBuffer
+	bb.WriteString("a")
+
+	c, err := bb.ReadByte()
+	if err != nil || c != 'a' {
+		t.Fatalf("unexpected ReadByte: c=%c, err=%v", c, err)
+	}
+
+	if err := bb.UnreadByte(); err != nil {
+		t.Fatalf("unexpected error from UnreadByte: %s", err)
+	}
+
+	c, err = bb.ReadByte()
+	if err != nil || c != 'a' {
+		t.Fatalf("unexpected ReadByte after UnreadByte: c=%c, err=%v", c, err)
+	}
+
+	if _, err := bb.ReadByte(); err != io.EOF {
+		t.Fatalf("expected io.EOF once the buffer is drained, got err=%v", err)
+	}
+}
+
+func TestByteBufferNext(t *testing.T) {
+	var bb ByteBuffer
+	bb.WriteString("foobar")
+
+	if s := string(bb.Next(3)); s != "foo" {
+		t.Fatalf("unexpected Next(3): %q", s)
+	}
+	if s := string(bb.Next(100)); s != "bar" {
+		t.Fatalf("unexpected Next(100): %q", s)
+	}
+	if s := string(bb.Next(1)); s != "" {
+		t.Fatalf("unexpected Next(1) on a drained buffer: %q", s)
+	}
+}
+
+func TestByteBufferLenBytesReflectReadOffset(t *testing.T) {
+	var bb ByteBuffer
+	bb.WriteString("foobar")
+
+	if n := bb.Len(); n != 6 {
+		t.Fatalf("unexpected Len() before any read: %d", n)
+	}
+
+	_, _ = bb.Read(make([]byte, 3)) // consume "foo"
+
+	if n := bb.Len(); n != 3 {
+		t.Fatalf("unexpected Len() after partial read: %d, want 3", n)
+	}
+	if s := string(bb.Bytes()); s != "bar" {
+		t.Fatalf("unexpected Bytes() after partial read: %q, want %q", s, "bar")
+	}
+	if s := bb.String(); s != "bar" {
+		t.Fatalf("unexpected String() after partial read: %q, want %q", s, "bar")
+	}
+}
+
+func TestByteBufferTruncate(t *testing.T) {
+	var bb ByteBuffer
+	bb.WriteString("foobar")
+	_, _ = bb.ReadByte() // consume 'f', advancing the read offset
+
+	bb.Truncate(2)
+	if s := string(bb.Next(100)); s != "oo" {
+		t.Fatalf("unexpected content after Truncate(2): %q", s)
+	}
+}
+
+func TestByteBufferWriteToDrainsFromReadOffset(t *testing.T) {
+	var bb ByteBuffer
+	bb.WriteString("foobar")
+	_, _ = bb.Read(make([]byte, 3)) // consume "foo"
+
+	var w sliceWriter
+	n, err := bb.WriteTo(&w)
+	if err != nil || n != 3 || string(w.b) != "bar" {
+		t.Fatalf("unexpected WriteTo: n=%d, err=%v, w=%q", n, err, w.b)
+	}
+
+	// a fully-drained buffer collapses, so a subsequent write starts
+	// from a clean slate instead of growing without bound.
+	bb.WriteString("baz")
+	if s := bb.String(); s != "baz" || len(bb.buf) != 3 {
+		t.Fatalf("unexpected content after collapse: %q", s)
+	}
+}
+
+func TestByteBufferResetClearsReadOffset(t *testing.T) {
+	var bb ByteBuffer
+	bb.WriteString("foobar")
+	_, _ = bb.ReadByte()
+
+	bb.Reset()
+	bb.WriteString("baz")
+	if s := string(bb.Next(100)); s != "baz" {
+		t.Fatalf("unexpected content after Reset: %q", s)
+	}
+}
+
+type sliceWriter struct {
+	b []byte
+}
+
+func (w *sliceWriter) Write(p []byte) (int, error) {
+	w.b = append(w.b, p...)
+	return len(p), nil
+}
@@ -16,19 +16,24 @@ func TestByteBufferWriteTo(t *testing.T) {
 
 	wt := (io.WriterTo)(&bb)
 	var w bytes.Buffer
-	for i := 0; i < 10; i++ {
-		n, err := wt.WriteTo(&w)
-		if n != int64(len(expectedS)) {
-			t.Fatalf("unexpected n returned from WriteTo: %d. Expecting %d", n, len(expectedS))
-		}
-		if err != nil {
-			t.Fatalf("unexpected error: %s", err)
-		}
-		s := string(w.Bytes())
-		if s != expectedS {
-			t.Fatalf("unexpected string written %q. Expecting %q", s, expectedS)
-		}
-		w.Reset()
+	n, err := wt.WriteTo(&w)
+	if n != int64(len(expectedS)) {
+		t.Fatalf("unexpected n returned from WriteTo: %d. Expecting %d", n, len(expectedS))
+	}
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	s := string(w.Bytes())
+	if s != expectedS {
+		t.Fatalf("unexpected string written %q. Expecting %q", s, expectedS)
+	}
+
+	// WriteTo drains from the read offset, so a fully-drained buffer has
+	// nothing left to give a second call.
+	w.Reset()
+	n, err = wt.WriteTo(&w)
+	if n != 0 || err != nil {
+		t.Fatalf("unexpected second WriteTo: n=%d, err=%v. Expecting n=0, err=nil", n, err)
 	}
 }
 
@@ -59,10 +64,10 @@ func testByteBufferGetPut(t *testing.T) {
 	for i := 0; i < 10; i++ {
 		expectedS := fmt.Sprintf("num %d", i)
 		b := Get()
-		b.B = append(b.B, "num "...)
-		b.B = append(b.B, fmt.Sprintf("%d", i)...)
-		if string(b.B) != expectedS {
-			t.Fatalf("unexpected result: %q. Expecting %q", b.B, expectedS)
+		b.WriteString("num ")
+		b.WriteString(fmt.Sprintf("%d", i))
+		if b.String() != expectedS {
+			t.Fatalf("unexpected result: %q. Expecting %q", b.String(), expectedS)
 		}
 		Put(b)
 	}
@@ -4,6 +4,7 @@ import (
 	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 const (
@@ -37,23 +38,120 @@ const (
 	calibrateDefaultSizeAdjustmentsFactorDenom  = fractionDenominator / calibrateDSASGcd                                       // simplified denominator of calibrateDefaultSizeAdjustmentsFactor
 )
 
+// PoolOptions configures the bucket geometry and retention policy of a
+// Pool created via NewPool.
+//
+// Any field left at its zero value falls back to the same default that a
+// zero-value Pool{} uses, so PoolOptions{} and NewPool(PoolOptions{})
+// behave exactly like Pool{}.
+type PoolOptions struct {
+	// MinBitSize is the log2 size of the smallest bucket, e.g. 6 means a
+	// 64-byte smallest bucket. Defaults to 6.
+	MinBitSize int
+
+	// Steps is the number of buckets; bucket i holds buffers of capacity
+	// up to 1<<(MinBitSize+i), and the last bucket also holds larger ones.
+	// Defaults to 20.
+	Steps int
+
+	// CalibrateCallsThreshold is the number of Put calls landing in a
+	// single bucket that triggers a calibration pass. Defaults to 42000.
+	CalibrateCallsThreshold int
+
+	// MaxPoolableCap, if non-zero, hard-caps the buffer capacity Put will
+	// ever retain, regardless of what calibration computes. Buffers
+	// larger than this are always discarded instead of pooled.
+	MaxPoolableCap int
+}
+
 // Pool represents byte buffer pool.
 //
 // Distinct pools may be used for distinct types of byte buffers.
 // Properly determined byte buffer types with their own pools may help reducing
 // memory waste.
 type Pool struct {
-	calls       [steps]uint64
+	// geometry, configurable via NewPool; a zero value means "use the
+	// package default" and is resolved once by init().
+	minBitSize              uint64
+	steps                   uint64
+	calibrateCallsThreshold uint64
+	maxPoolableCap          uint64
+
+	calls       []uint64
 	calibrating uint64
 
 	defaultSize uint64
 	maxSize     uint64
 
-	pool sync.Pool
+	gets         uint64
+	puts         uint64
+	misses       uint64
+	discards     uint64
+	calibrations uint64
+
+	pools []atomic.Value // each holds a *sync.Pool
+
+	// evictCalls counts Put calls per storage bucket since the last
+	// evictor tick. It's deliberately separate from calls: calls feeds
+	// calibrate()'s percentile math and must only reset on a calibration
+	// pass, while the evictor resets its own counter every tick to
+	// detect idleness. Sharing one counter between the two would reset
+	// calibrate's sample out from under it on every evictor tick,
+	// silently disabling calibration whenever an evictor is running.
+	evictCalls []uint64
+
+	initOnce sync.Once
+
+	evictMu   sync.Mutex
+	evictStop chan struct{}
+	evictDone chan struct{}
 }
 
 var defaultPool Pool
 
+// NewPool creates a Pool with the given bucket geometry and retention
+// policy. Fields left at their zero value in opts fall back to the same
+// defaults a bare Pool{} uses.
+func NewPool(opts PoolOptions) *Pool {
+	p := &Pool{
+		minBitSize:              uint64(opts.MinBitSize),
+		steps:                   uint64(opts.Steps),
+		calibrateCallsThreshold: uint64(opts.CalibrateCallsThreshold),
+		maxPoolableCap:          uint64(opts.MaxPoolableCap),
+	}
+	p.init()
+	return p
+}
+
+// init resolves zero-valued geometry fields to their package defaults and
+// allocates the per-bucket slices. It runs at most once per Pool, so it's
+// safe to call from every exported entry point, including on a bare
+// Pool{} that was never passed through NewPool.
+func (p *Pool) init() {
+	p.initOnce.Do(func() {
+		if p.minBitSize == 0 {
+			p.minBitSize = minBitSize
+		}
+		if p.steps == 0 {
+			p.steps = steps
+		}
+		if p.calibrateCallsThreshold == 0 {
+			p.calibrateCallsThreshold = calibrateCallsThreshold
+		}
+		p.calls = make([]uint64, p.steps)
+		p.evictCalls = make([]uint64, p.steps)
+		p.pools = make([]atomic.Value, p.steps)
+		for i := range p.pools {
+			p.pools[i].Store(&sync.Pool{})
+		}
+	})
+}
+
+// bucketPool returns the current sync.Pool backing bucket idx.
+func (p *Pool) bucketPool(idx int) *sync.Pool {
+	return p.pools[idx].Load().(*sync.Pool)
+}
+
 // Get returns an empty byte buffer from the pool.
 //
 // Got byte buffer may be returned to the pool via Put call.
@@ -66,13 +164,49 @@ func Get() *ByteBuffer { return defaultPool.Get() }
 // The byte buffer may be returned to the pool via Put after the use
 // in order to minimize GC overhead.
 func (p *Pool) Get() *ByteBuffer {
-	v := p.pool.Get()
-	if v != nil {
-		return v.(*ByteBuffer)
+	p.init()
+	return p.GetSized(int(atomic.LoadUint64(&p.defaultSize)))
+}
+
+// GetSized returns a byte buffer with zero length and cap >= n from the pool.
+//
+// GetSized pulls the buffer out of the bucket matching n, falling back to
+// progressively larger buckets and finally to a fresh allocation if every
+// matching bucket is empty. This avoids the doubling-reallocation loop that
+// Write/ReadFrom would otherwise perform on a buffer that started too small.
+//
+// The byte buffer may be returned to the pool via Put after use in order to
+// minimize GC overhead.
+func GetSized(n int) *ByteBuffer { return defaultPool.GetSized(n) }
+
+// GetSized returns a byte buffer with zero length and cap >= n from the pool.
+func (p *Pool) GetSized(n int) *ByteBuffer {
+	p.init()
+	atomic.AddUint64(&p.gets, 1)
+
+	idx := p.index(n)
+	for i := idx; i < int(p.steps); i++ {
+		sp := p.bucketPool(i)
+		v := sp.Get()
+		if v == nil {
+			continue
+		}
+		b := v.(*ByteBuffer)
+		if cap(b.buf) >= n {
+			return b
+		}
+		sp.Put(b)
 	}
-	return &ByteBuffer{
-		B: make([]byte, 0, atomic.LoadUint64(&p.defaultSize)),
+
+	atomic.AddUint64(&p.misses, 1)
+	bucketCap := p.bucketCap(idx)
+	if bucketCap < n {
+		// idx was clamped to the last bucket because n exceeds the
+		// largest bucket's capacity; honor the cap >= n contract with a
+		// one-off allocation sized to n instead.
+		bucketCap = n
 	}
+	return &ByteBuffer{buf: make([]byte, 0, bucketCap)}
 }
 
 // Put returns byte buffer to the pool.
@@ -85,17 +219,46 @@ func Put(b *ByteBuffer) { defaultPool.Put(b) }
 //
 // The buffer mustn't be accessed after returning to the pool.
 func (p *Pool) Put(b *ByteBuffer) {
-	idx := index(len(b.B))
-
-	if atomic.AddUint64(&p.calls[idx], 1) > calibrateCallsThreshold {
+	p.init()
+	atomic.AddUint64(&p.puts, 1)
+
+	// Calibration tracks len(b.buf), the size actually requested/used by
+	// callers, not cap(b.buf): a bucket's buffer can carry a far larger
+	// capacity than what it's being used for, e.g. after GetSized(n)
+	// fell back to a larger bucket. Feeding that inherited capacity into
+	// the calibration counters would make defaultSize drift upward
+	// toward whatever capacity happens to be in circulation instead of
+	// tracking real usage.
+	lenIdx := p.index(len(b.buf))
+	if atomic.AddUint64(&p.calls[lenIdx], 1) > p.calibrateCallsThreshold {
 		p.calibrate()
 	}
 
 	maxSize := int(atomic.LoadUint64(&p.maxSize))
-	if maxSize == 0 || cap(b.B) <= maxSize {
+	if p.maxPoolableCap > 0 {
+		mpc := int(p.maxPoolableCap)
+		if maxSize == 0 || mpc < maxSize {
+			maxSize = mpc
+		}
+	}
+
+	if maxSize == 0 || cap(b.buf) <= maxSize {
+		// Storage routing, by contrast, uses cap(b.buf): it determines
+		// which bucket can actually satisfy a future GetSized(n) without
+		// reallocating, which depends on capacity, not length.
+		capIdx := p.index(cap(b.buf))
+		atomic.AddUint64(&p.evictCalls[capIdx], 1)
 		b.Reset()
-		p.pool.Put(b)
+		p.bucketPool(capIdx).Put(b)
+		return
 	}
+
+	atomic.AddUint64(&p.discards, 1)
+}
+
+// bucketCap returns the capacity of buffers stored in bucket idx.
+func (p *Pool) bucketCap(idx int) int {
+	return 1 << (p.minBitSize + uint64(idx))
 }
 
 func (p *Pool) calibrate() {
@@ -103,16 +266,17 @@ func (p *Pool) calibrate() {
 		return
 	}
 
-	a := make(callSizes, 0, steps)
+	stepsN := int(p.steps)
+	a := make(callSizes, 0, stepsN)
 
 	callsSum := uint64(0)
 
-	for i := uint64(0); i < steps; i++ {
+	for i := 0; i < stepsN; i++ {
 		calls := atomic.SwapUint64(&p.calls[i], 0)
 		callsSum += calls
 		a = append(a, callSize{
 			calls: calls,
-			size:  minSize << i,
+			size:  uint64(p.bucketCap(i)),
 		})
 	}
 	sort.Sort(a)
@@ -140,7 +304,7 @@ func (p *Pool) calibrate() {
 	// a[0].calls ~= calibrateCallsThreshold + maybe small R <<<< (MaxUint64 / fractionDenominator)
 	defSizeAdjustCallsThreshold := (a[0].calls * calibrateDefaultSizeAdjustmentsFactorNumer) / calibrateDefaultSizeAdjustmentsFactorDenom // == uint64(a[0].calls * calibrateDefaultSizeAdjustmentsFactor)
 
-	for i := 1; i < steps; i++ {
+	for i := 1; i < stepsN; i++ {
 
 		if callsSum > maxSum {
 			break
@@ -161,10 +325,160 @@ func (p *Pool) calibrate() {
 
 	atomic.StoreUint64(&p.defaultSize, defaultSize)
 	atomic.StoreUint64(&p.maxSize, maxSize)
+	atomic.AddUint64(&p.calibrations, 1)
 
 	atomic.StoreUint64(&p.calibrating, 0)
 }
 
+// PoolStats is a snapshot of a Pool's runtime counters, as returned by
+// Pool.Stats().
+type PoolStats struct {
+	// Gets is the number of Get/GetSized calls served.
+	Gets uint64
+
+	// Puts is the number of Put calls served.
+	Puts uint64
+
+	// Misses is the number of Get/GetSized calls that found no suitable
+	// buffer in any bucket and allocated a new one.
+	Misses uint64
+
+	// Discards is the number of Put calls whose buffer capacity exceeded
+	// MaxSize or the pool's MaxPoolableCap and was therefore dropped
+	// instead of returned to a bucket.
+	Discards uint64
+
+	// Calls is a snapshot of the per-bucket Put call counters used for
+	// calibration. It is zeroed by calibrate(), so a bucket's count only
+	// reflects calls since the last calibration.
+	Calls []uint64
+
+	// DefaultSize is the capacity Get requests from GetSized; Get returns
+	// an empty buffer with at least this capacity.
+	DefaultSize uint64
+
+	// MaxSize is the largest buffer capacity Put will retain in the pool,
+	// as computed by calibration. Zero means no limit has been computed
+	// yet. A non-zero MaxPoolableCap lowers the limit further.
+	MaxSize uint64
+
+	// Calibrations is the number of times calibrate() has run.
+	Calibrations uint64
+}
+
+// Stats returns a snapshot of the pool's runtime statistics.
+func (p *Pool) Stats() PoolStats {
+	p.init()
+	s := PoolStats{
+		Gets:         atomic.LoadUint64(&p.gets),
+		Puts:         atomic.LoadUint64(&p.puts),
+		Misses:       atomic.LoadUint64(&p.misses),
+		Discards:     atomic.LoadUint64(&p.discards),
+		DefaultSize:  atomic.LoadUint64(&p.defaultSize),
+		MaxSize:      atomic.LoadUint64(&p.maxSize),
+		Calibrations: atomic.LoadUint64(&p.calibrations),
+		Calls:        make([]uint64, len(p.calls)),
+	}
+	for i := range p.calls {
+		s.Calls[i] = atomic.LoadUint64(&p.calls[i])
+	}
+	return s
+}
+
+// ResetStats zeroes the pool's Gets/Puts/Misses/Discards/Calibrations
+// counters. It doesn't affect the calibrated DefaultSize/MaxSize or the
+// pooled buffers themselves.
+func (p *Pool) ResetStats() {
+	p.init()
+	atomic.StoreUint64(&p.gets, 0)
+	atomic.StoreUint64(&p.puts, 0)
+	atomic.StoreUint64(&p.misses, 0)
+	atomic.StoreUint64(&p.discards, 0)
+	atomic.StoreUint64(&p.calibrations, 0)
+}
+
+// idleRoundsToEvict is the number of consecutive idle StartEvictor ticks a
+// bucket must see before it is drained.
+const idleRoundsToEvict = 3
+
+// StartEvictor launches a background goroutine that inspects per-bucket
+// Put counts every interval and drains any bucket that has seen no Put
+// calls for idleRoundsToEvict consecutive ticks, replacing its sync.Pool
+// with a fresh empty one. This lets a long-running server shed memory
+// held by buffers from a past traffic spike without waiting on a GC.
+//
+// StartEvictor tracks idleness with its own per-bucket counters, separate
+// from the ones calibrate() consumes, so running an evictor never perturbs
+// calibration.
+//
+// Calling StartEvictor while an evictor is already running is a no-op.
+// The evictor must be stopped with Stop.
+func (p *Pool) StartEvictor(interval time.Duration) {
+	p.init()
+
+	p.evictMu.Lock()
+	defer p.evictMu.Unlock()
+
+	if p.evictStop != nil {
+		return
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	p.evictStop = stop
+	p.evictDone = done
+
+	go p.runEvictor(interval, stop, done)
+}
+
+// Stop stops the background evictor started by StartEvictor, waiting for
+// it to exit. It is a no-op if no evictor is running.
+func (p *Pool) Stop() {
+	p.evictMu.Lock()
+	stop := p.evictStop
+	done := p.evictDone
+	p.evictStop = nil
+	p.evictDone = nil
+	p.evictMu.Unlock()
+
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
+
+func (p *Pool) runEvictor(interval time.Duration, stop, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	idleRounds := make([]int, p.steps)
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			for i := 0; i < int(p.steps); i++ {
+				// Swap-and-clear its own counter so each tick only sees
+				// Put calls made since the last tick, without touching
+				// calls, which calibrate() depends on.
+				if atomic.SwapUint64(&p.evictCalls[i], 0) != 0 {
+					idleRounds[i] = 0
+					continue
+				}
+				idleRounds[i]++
+				if idleRounds[i] >= idleRoundsToEvict {
+					p.pools[i].Store(&sync.Pool{})
+					idleRounds[i] = 0
+				}
+			}
+		}
+	}
+}
+
 type callSize struct {
 	calls uint64
 	size  uint64
@@ -184,16 +498,16 @@ func (ci callSizes) Swap(i, j int) {
 	ci[i], ci[j] = ci[j], ci[i]
 }
 
-func index(n int) int {
+func (p *Pool) index(n int) int {
 	n--
-	n >>= minBitSize
+	n >>= int(p.minBitSize)
 	idx := 0
 	for n > 0 {
 		n >>= 1
 		idx++
 	}
-	if idx >= steps {
-		idx = steps - 1
+	if stepsN := int(p.steps); idx >= stepsN {
+		idx = stepsN - 1
 	}
 	return idx
 }
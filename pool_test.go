@@ -3,6 +3,7 @@ package bytebufferpool
 import (
 	"math/bits"
 	"math/rand"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -25,7 +26,9 @@ func TestIndex(t *testing.T) {
 }
 
 func testIndex(t *testing.T, n, expectedIdx int) {
-	idx := index(n)
+	var p Pool
+	p.init()
+	idx := p.index(n)
 	if idx != expectedIdx {
 		t.Fatalf("unexpected idx for n=%d: %d. Expecting %d", n, idx, expectedIdx)
 	}
@@ -78,6 +81,154 @@ func TestPoolCalibrateWithAdjustment(t *testing.T) {
 	}
 }
 
+func TestPoolGetSized(t *testing.T) {
+	var p Pool
+
+	sizes := []int{0, 1, minSize, minSize + 1, 10 * minSize, maxSize}
+	for _, n := range sizes {
+		b := p.GetSized(n)
+		if b.Len() != 0 {
+			t.Fatalf("non-empty byte buffer returned from GetSized(%d)", n)
+		}
+		if b.Cap() < n {
+			t.Fatalf("GetSized(%d) returned buffer with cap=%d, want cap >= %d", n, b.Cap(), n)
+		}
+		p.Put(b)
+	}
+
+	// a buffer put with a given capacity must come back out of GetSized
+	// for that same capacity instead of triggering a fresh allocation.
+	b := p.GetSized(minSize)
+	wantCap := b.Cap()
+	p.Put(b)
+
+	b2 := p.GetSized(minSize)
+	if b2.Cap() != wantCap {
+		t.Fatalf("GetSized(%d) didn't reuse the pooled buffer: got cap=%d, want cap=%d", minSize, b2.Cap(), wantCap)
+	}
+	p.Put(b2)
+}
+
+func TestPoolGetSizedOversized(t *testing.T) {
+	var p Pool
+
+	// n beyond the largest bucket clamps idx to steps-1, whose bucketCap
+	// can be smaller than n; GetSized must still honor cap >= n.
+	sizes := []int{maxSize + 1, maxSize * 4}
+	for _, n := range sizes {
+		b := p.GetSized(n)
+		if b.Cap() < n {
+			t.Fatalf("GetSized(%d) returned buffer with cap=%d, want cap >= %d", n, b.Cap(), n)
+		}
+		p.Put(b)
+	}
+}
+
+func TestPoolStats(t *testing.T) {
+	var p Pool
+
+	b := p.GetSized(minSize)
+	p.Put(b)
+	b = p.GetSized(minSize)
+	p.Put(b)
+
+	s := p.Stats()
+	if s.Gets != 2 {
+		t.Fatalf("unexpected Gets: %d. Expecting 2", s.Gets)
+	}
+	if s.Puts != 2 {
+		t.Fatalf("unexpected Puts: %d. Expecting 2", s.Puts)
+	}
+	if s.Misses != 1 {
+		t.Fatalf("unexpected Misses: %d. Expecting 1", s.Misses)
+	}
+
+	p.ResetStats()
+	s = p.Stats()
+	if s.Gets != 0 || s.Puts != 0 || s.Misses != 0 || s.Discards != 0 || s.Calibrations != 0 {
+		t.Fatalf("ResetStats didn't clear counters: %+v", s)
+	}
+}
+
+func TestNewPoolCustomGeometry(t *testing.T) {
+	p := NewPool(PoolOptions{
+		MinBitSize:     10, // 1 KiB smallest bucket
+		Steps:          4,
+		MaxPoolableCap: 8192,
+	})
+
+	b := p.GetSized(100)
+	if b.Cap() < 1024 {
+		t.Fatalf("unexpected cap for GetSized(100): %d, want >= 1024", b.Cap())
+	}
+	p.Put(b)
+
+	big := NewByteBuffer(make([]byte, 0, 1<<20))
+	p.Put(big)
+	if s := p.Stats(); s.Discards != 1 {
+		t.Fatalf("unexpected Discards after putting an oversize buffer: %d, want 1", s.Discards)
+	}
+}
+
+func TestNewPoolDefaultsMatchBarePool(t *testing.T) {
+	p := NewPool(PoolOptions{})
+	var bare Pool
+	bare.init()
+
+	if p.index(minSize+1) != bare.index(minSize+1) {
+		t.Fatalf("NewPool(PoolOptions{}) geometry diverged from a bare Pool{}")
+	}
+}
+
+func TestPoolEvictor(t *testing.T) {
+	var p Pool
+
+	b := p.GetSized(minSize)
+	p.Put(b)
+
+	idx := p.index(minSize)
+	before := p.bucketPool(idx)
+
+	p.StartEvictor(5 * time.Millisecond)
+	defer p.Stop()
+
+	deadline := time.After(time.Second)
+	for p.bucketPool(idx) == before {
+		select {
+		case <-deadline:
+			t.Fatalf("evictor never drained the idle bucket")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+}
+
+// TestPoolEvictorDoesNotStarveCalibration guards against the evictor and
+// calibrate() fighting over the same per-bucket counters: an evictor
+// ticking faster than calibrateCallsThreshold Put calls can accumulate
+// must not reset calls out from under calibrate(), or calibration would
+// never fire while an evictor runs.
+func TestPoolEvictorDoesNotStarveCalibration(t *testing.T) {
+	var p Pool
+	p.init()
+
+	p.StartEvictor(time.Millisecond)
+	defer p.Stop()
+
+	for i := uint64(0); i <= p.calibrateCallsThreshold; i++ {
+		b := p.GetSized(minSize)
+		p.Put(b)
+	}
+
+	deadline := time.After(time.Second)
+	for atomic.LoadUint64(&p.calibrations) == 0 {
+		select {
+		case <-deadline:
+			t.Fatalf("calibration never ran while an evictor was active")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+}
+
 func TestPoolVariousSizesSerial(t *testing.T) {
 	testPoolVariousSizes(t)
 }
@@ -128,10 +279,10 @@ func testPoolVariousSizes(t *testing.T) {
 
 func testGetPut(t *testing.T, n int) {
 	bb := Get()
-	if len(bb.B) > 0 {
+	if len(bb.buf) > 0 {
 		t.Fatalf("non-empty byte buffer returned from acquire")
 	}
-	bb.B = allocNBytes(bb.B, n)
+	bb.buf = allocNBytes(bb.buf, n)
 	Put(bb)
 }
 
@@ -146,7 +297,7 @@ func allocNBytes(dst []byte, n int) []byte {
 
 func allocNBytesInP(p *Pool, n int) {
 	b := p.Get()
-	b.B = allocNBytes(b.B, n)
+	b.buf = allocNBytes(b.buf, n)
 	p.Put(b)
 }
 
@@ -172,8 +323,8 @@ func allocNMBytesInP(p *Pool, n, m int) {
 	// ATN! preserve order, its important
 	bn := p.Get()
 	bm := p.Get()
-	bn.B = allocNBytes(bn.B, n)
-	bm.B = allocNBytes(bm.B, m)
+	bn.buf = allocNBytes(bn.buf, n)
+	bm.buf = allocNBytes(bm.buf, m)
 	p.Put(bn)
 	p.Put(bm)
 }